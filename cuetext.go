@@ -0,0 +1,182 @@
+package webvtt
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// CueNodeKind identifies the kind of a CueNode.
+type CueNodeKind int
+
+const (
+	CueNodeText CueNodeKind = iota
+	CueNodeClass
+	CueNodeItalic
+	CueNodeBold
+	CueNodeUnderline
+	CueNodeRuby
+	CueNodeRubyText
+	CueNodeLang
+	CueNodeVoice
+	CueNodeTimestamp
+)
+
+// CueNode is one node of a cue's parsed cue-text tree: either a run of
+// plain text, a timestamp tag, or a span tag (<c>, <i>, <b>, <u>,
+// <ruby>/<rt>, <lang>, <v>) with nested children.
+type CueNode struct {
+	Kind CueNodeKind
+	// Text holds the literal text for a CueNodeText node, with character
+	// references such as "&amp;" decoded.
+	Text string
+	// Classes holds the dot-separated class list any tag may carry, e.g.
+	// "loud" and "whisper" for <c.loud.whisper> or <v.loud Bob>.
+	Classes []string
+	// Voice holds the speaker name for a CueNodeVoice node.
+	Voice string
+	// Lang holds the language subtag for a CueNodeLang node.
+	Lang string
+	// Time holds the cue-relative time for a CueNodeTimestamp node.
+	Time time.Duration
+	// Children holds the nested nodes of a span tag.
+	Children []CueNode
+}
+
+// cueTimestampTagRegex matches the contents of a karaoke timestamp tag,
+// e.g. "00:00:12.500" or "01:02:03.456".
+var cueTimestampTagRegex = regexp.MustCompile(`^(\d{1,2}:)?\d{2}:\d{2}\.\d{3}$`)
+
+// cueTextUnescaper decodes the named character references of the cue-text
+// grammar in text runs; it is the inverse of the writer's escapeCueText.
+var cueTextUnescaper = strings.NewReplacer(
+	"&amp;", "&", "&lt;", "<", "&gt;", ">",
+	"&lrm;", "‎", "&rlm;", "‏", "&nbsp;", " ",
+)
+
+var cueNodeKindByTag = map[string]CueNodeKind{
+	"c":    CueNodeClass,
+	"i":    CueNodeItalic,
+	"b":    CueNodeBold,
+	"u":    CueNodeUnderline,
+	"ruby": CueNodeRuby,
+	"rt":   CueNodeRubyText,
+	"lang": CueNodeLang,
+	"v":    CueNodeVoice,
+}
+
+// parseCueNodes parses cue payload text into a CueNode tree following the
+// WebVTT cue-text grammar.
+func parseCueNodes(text string) []CueNode {
+	p := &cueTextParser{s: []rune(text)}
+	return p.parseNodes("")
+}
+
+type cueTextParser struct {
+	s   []rune
+	pos int
+}
+
+// parseNodes consumes nodes until it finds a closing tag matching
+// stopTag (if stopTag is non-empty) or the input is exhausted.
+func (p *cueTextParser) parseNodes(stopTag string) []CueNode {
+	var nodes []CueNode
+	var text strings.Builder
+
+	flush := func() {
+		if text.Len() > 0 {
+			nodes = append(nodes, CueNode{Kind: CueNodeText, Text: cueTextUnescaper.Replace(text.String())})
+			text.Reset()
+		}
+	}
+
+	for p.pos < len(p.s) {
+		if p.s[p.pos] != '<' {
+			text.WriteRune(p.s[p.pos])
+			p.pos++
+			continue
+		}
+
+		end := indexRune(p.s[p.pos+1:], '>')
+		if end < 0 {
+			// Unterminated tag: treat the rest of the input as text.
+			text.WriteString(string(p.s[p.pos:]))
+			p.pos = len(p.s)
+			break
+		}
+		content := string(p.s[p.pos+1 : p.pos+1+end])
+		p.pos += end + 2
+
+		if name, ok := strings.CutPrefix(content, "/"); ok {
+			flush()
+			if name == stopTag {
+				return nodes
+			}
+			// Mismatched close tag: ignore it and keep parsing, consistent
+			// with how parseBlock/parseVoices tolerate malformed input.
+			continue
+		}
+
+		if d, ok := parseCueTimestampTag(content); ok {
+			flush()
+			nodes = append(nodes, CueNode{Kind: CueNodeTimestamp, Time: d})
+			continue
+		}
+
+		name, classes, annotation := parseCueTagHead(content)
+		kind, ok := cueNodeKindByTag[name]
+		if !ok {
+			// Unknown tag: skip the marker but keep its content inline.
+			continue
+		}
+
+		flush()
+		node := CueNode{Kind: kind, Classes: classes, Children: p.parseNodes(name)}
+		switch kind {
+		case CueNodeVoice:
+			node.Voice = annotation
+		case CueNodeLang:
+			node.Lang = annotation
+		}
+		nodes = append(nodes, node)
+	}
+
+	flush()
+	return nodes
+}
+
+// parseCueTagHead splits a tag's contents (without the surrounding <>)
+// into its name, dot-separated classes, and trailing annotation, e.g.
+// "v.loud Bob" -> ("v", ["loud"], "Bob").
+func parseCueTagHead(content string) (name string, classes []string, annotation string) {
+	head := content
+	if idx := strings.IndexAny(content, " \t"); idx >= 0 {
+		head = content[:idx]
+		annotation = strings.TrimSpace(content[idx+1:])
+	}
+	segments := strings.Split(head, ".")
+	if len(segments) > 1 {
+		classes = segments[1:]
+	}
+	return segments[0], classes, annotation
+}
+
+func parseCueTimestampTag(content string) (time.Duration, bool) {
+	if !cueTimestampTagRegex.MatchString(content) {
+		return 0, false
+	}
+	d, err := parseTimestamp(content)
+	if err != nil {
+		return 0, false
+	}
+	return d, true
+}
+
+func indexRune(s []rune, r rune) int {
+	for i, c := range s {
+		if c == r {
+			return i
+		}
+	}
+	return -1
+}
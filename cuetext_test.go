@@ -0,0 +1,91 @@
+package webvtt
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseCueNodes(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []CueNode
+	}{
+		{
+			name: "plain text",
+			text: "hello world",
+			want: []CueNode{{Kind: CueNodeText, Text: "hello world"}},
+		},
+		{
+			name: "voice with classes",
+			text: "<v.loud Bob>Hi there</v>",
+			want: []CueNode{{
+				Kind:    CueNodeVoice,
+				Classes: []string{"loud"},
+				Voice:   "Bob",
+				Children: []CueNode{
+					{Kind: CueNodeText, Text: "Hi there"},
+				},
+			}},
+		},
+		{
+			name: "class span with multiple classes",
+			text: "<c.yellow.bg_red>warning</c>",
+			want: []CueNode{{
+				Kind:     CueNodeClass,
+				Classes:  []string{"yellow", "bg_red"},
+				Children: []CueNode{{Kind: CueNodeText, Text: "warning"}},
+			}},
+		},
+		{
+			name: "nested italic and bold",
+			text: "<i><b>loud</b></i>",
+			want: []CueNode{{
+				Kind: CueNodeItalic,
+				Children: []CueNode{{
+					Kind:     CueNodeBold,
+					Children: []CueNode{{Kind: CueNodeText, Text: "loud"}},
+				}},
+			}},
+		},
+		{
+			name: "ruby and ruby text",
+			text: "<ruby>漢<rt>kan</rt></ruby>",
+			want: []CueNode{{
+				Kind: CueNodeRuby,
+				Children: []CueNode{
+					{Kind: CueNodeText, Text: "漢"},
+					{Kind: CueNodeRubyText, Children: []CueNode{{Kind: CueNodeText, Text: "kan"}}},
+				},
+			}},
+		},
+		{
+			name: "lang span",
+			text: "<lang en>hello</lang>",
+			want: []CueNode{{
+				Kind:     CueNodeLang,
+				Lang:     "en",
+				Children: []CueNode{{Kind: CueNodeText, Text: "hello"}},
+			}},
+		},
+		{
+			name: "karaoke timestamp splits text",
+			text: "one<00:00:12.500>two",
+			want: []CueNode{
+				{Kind: CueNodeText, Text: "one"},
+				{Kind: CueNodeTimestamp, Time: 12*time.Second + 500*time.Millisecond},
+				{Kind: CueNodeText, Text: "two"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseCueNodes(tt.text)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseCueNodes(%q) = %#v, want %#v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// Package mp4 decodes WebVTT cues carried inside ISO BMFF ("fragmented
+// MP4") samples, as used by DASH and HLS fMP4 subtitle tracks and defined
+// by ISO/IEC 14496-30. It turns a 'wvtt' sample entry's configuration box
+// and per-sample cue boxes into the same webvtt.Cue/webvtt.Block values
+// that webvtt.Parse produces for sidecar .vtt files.
+package mp4
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/Songmu/go-webvtt"
+)
+
+// Config holds the WebVTT configuration carried in a 'wvtt' sample entry's
+// vttC box: the WEBVTT header text (which may include default cue
+// settings, REGION, and STYLE blocks) that applies to every sample in the
+// track.
+type Config struct {
+	Header string
+}
+
+// ParseConfig parses a vttC (WebVTTConfigurationBox) payload. The box
+// contains nothing but a UTF-8 string, so this just trims any trailing
+// NUL padding some muxers add.
+func ParseConfig(data []byte) (Config, error) {
+	return Config{Header: string(trimNUL(data))}, nil
+}
+
+// ParseSample decodes a single wvtt sample payload into the blocks it
+// contains. pts and duration are the sample's presentation time and
+// duration, taken from the track's timing information, and become the
+// start/end time of every cue the sample carries.
+//
+// A sample is a sequence of boxes: one or more 'vttc' cue boxes (each
+// optionally containing 'iden', 'sttg', and 'payl' child boxes, and
+// rarely a 'vsid' source-id box), a single empty-cue 'vtte' box meaning
+// the sample carries no cues, or a 'vttx' additional-cue box carrying
+// out-of-band text such as an updated REGION or STYLE block.
+func ParseSample(data []byte, pts, duration time.Duration) ([]webvtt.Block, error) {
+	boxes, err := readBoxes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []webvtt.Block
+	for _, b := range boxes {
+		switch b.typ {
+		case "vtte":
+			// Empty cue: the sample intentionally carries no cues.
+		case "vttc":
+			cue, err := parseCueBox(b.data, pts, duration)
+			if err != nil {
+				return nil, err
+			}
+			blocks = append(blocks, cue)
+		case "vttx":
+			children, err := readBoxes(b.data)
+			if err != nil {
+				return nil, err
+			}
+			blocks = append(blocks, webvtt.Note{Text: string(trimNUL(findBoxData(children, "payl")))})
+		default:
+			return nil, fmt.Errorf("mp4: unexpected sample box type %q", b.typ)
+		}
+	}
+	return blocks, nil
+}
+
+func parseCueBox(data []byte, pts, duration time.Duration) (webvtt.Cue, error) {
+	boxes, err := readBoxes(data)
+	if err != nil {
+		return webvtt.Cue{}, err
+	}
+
+	cue := webvtt.Cue{StartTime: pts, EndTime: pts + duration}
+	for _, b := range boxes {
+		switch b.typ {
+		case "iden":
+			cue.ID = string(trimNUL(b.data))
+		case "sttg":
+			cue.Settings = webvtt.ParseCueSettings(string(b.data))
+		case "payl":
+			text := string(trimNUL(b.data))
+			cue.Voices = webvtt.ParseCueText(text)
+			cue.Nodes = webvtt.ParseCueNodes(text)
+		case "vsid":
+			// Source id: which region/track a cue belongs to when cues
+			// from multiple sources are multiplexed. Not yet modeled on
+			// webvtt.Cue.
+		}
+	}
+	return cue, nil
+}
+
+type box struct {
+	typ  string
+	data []byte
+}
+
+// readBoxes splits data into a sequence of ISO BMFF boxes: a big-endian
+// uint32 size (or, when size == 1, a 64-bit largesize immediately after
+// the 4-byte type), followed by a 4-byte ASCII type and then the box
+// payload. size == 0 means "extends to the end of data".
+func readBoxes(data []byte) ([]box, error) {
+	var boxes []box
+	for len(data) > 0 {
+		if len(data) < 8 {
+			return nil, fmt.Errorf("mp4: truncated box header (%d bytes left)", len(data))
+		}
+		size := uint64(binary.BigEndian.Uint32(data[0:4]))
+		typ := string(data[4:8])
+		header := 8
+		if size == 1 {
+			if len(data) < 16 {
+				return nil, fmt.Errorf("mp4: truncated largesize box header")
+			}
+			size = binary.BigEndian.Uint64(data[8:16])
+			header = 16
+		} else if size == 0 {
+			size = uint64(len(data))
+		}
+		if size < uint64(header) || size > uint64(len(data)) {
+			return nil, fmt.Errorf("mp4: invalid box %q size %d", typ, size)
+		}
+		boxes = append(boxes, box{typ: typ, data: data[header:size]})
+		data = data[size:]
+	}
+	return boxes, nil
+}
+
+func findBoxData(boxes []box, typ string) []byte {
+	for _, b := range boxes {
+		if b.typ == typ {
+			return b.data
+		}
+	}
+	return nil
+}
+
+func trimNUL(b []byte) []byte {
+	for len(b) > 0 && b[len(b)-1] == 0 {
+		b = b[:len(b)-1]
+	}
+	return b
+}
@@ -0,0 +1,83 @@
+package mp4
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/Songmu/go-webvtt"
+)
+
+func makeBox(typ string, payload []byte) []byte {
+	b := make([]byte, 8+len(payload))
+	binary.BigEndian.PutUint32(b[0:4], uint32(len(b)))
+	copy(b[4:8], typ)
+	copy(b[8:], payload)
+	return b
+}
+
+func TestParseSample_Cue(t *testing.T) {
+	payl := makeBox("payl", []byte("Hello <v Bob>world</v>"))
+	sttg := makeBox("sttg", []byte("line:50% align:center"))
+	iden := makeBox("iden", []byte("cue-1"))
+	vttc := makeBox("vttc", append(append(append([]byte{}, iden...), sttg...), payl...))
+
+	blocks, err := ParseSample(vttc, 1500*time.Millisecond, 2*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != 1 {
+		t.Fatalf("got %d blocks, want 1", len(blocks))
+	}
+	cue, ok := blocks[0].(webvtt.Cue)
+	if !ok {
+		t.Fatalf("block is %T, want webvtt.Cue", blocks[0])
+	}
+	if cue.ID != "cue-1" {
+		t.Errorf("ID = %q, want %q", cue.ID, "cue-1")
+	}
+	if cue.StartTime != 1500*time.Millisecond || cue.EndTime != 3500*time.Millisecond {
+		t.Errorf("times = %v..%v, want 1.5s..3.5s", cue.StartTime, cue.EndTime)
+	}
+	if cue.Settings.Align != "center" {
+		t.Errorf("settings.Align = %q, want %q", cue.Settings.Align, "center")
+	}
+	if cue.Settings.Line == nil || cue.Settings.Line.Value != 50 || cue.Settings.Line.Unit != webvtt.LineUnitPercent {
+		t.Errorf("settings.Line = %+v, want {Value:50 Unit:Percent}", cue.Settings.Line)
+	}
+	if len(cue.Voices) != 2 || cue.Voices[0].Text != "Hello" || cue.Voices[1].Speaker != "Bob" || cue.Voices[1].Text != "world" {
+		t.Errorf("Voices = %+v, want [{Text:Hello} {Speaker:Bob Text:world}]", cue.Voices)
+	}
+	if len(cue.Nodes) != 2 {
+		t.Fatalf("got %d cue.Nodes, want 2", len(cue.Nodes))
+	}
+	if cue.Nodes[0].Kind != webvtt.CueNodeText || cue.Nodes[0].Text != "Hello " {
+		t.Errorf("Nodes[0] = %+v, want a text node \"Hello \"", cue.Nodes[0])
+	}
+	if cue.Nodes[1].Kind != webvtt.CueNodeVoice || cue.Nodes[1].Voice != "Bob" ||
+		len(cue.Nodes[1].Children) != 1 || cue.Nodes[1].Children[0].Text != "world" {
+		t.Errorf("Nodes[1] = %+v, want a voice node for Bob containing \"world\"", cue.Nodes[1])
+	}
+}
+
+func TestParseSample_Empty(t *testing.T) {
+	vtte := makeBox("vtte", nil)
+	blocks, err := ParseSample(vtte, 0, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != 0 {
+		t.Errorf("got %d blocks for an empty cue sample, want 0", len(blocks))
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := ParseConfig([]byte("WEBVTT\n\nREGION\nid:r0\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "WEBVTT\n\nREGION\nid:r0\n"
+	if cfg.Header != want {
+		t.Errorf("Header = %q, want %q", cfg.Header, want)
+	}
+}
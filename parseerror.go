@@ -0,0 +1,37 @@
+package webvtt
+
+import "fmt"
+
+// ParseError describes a single WebVTT block that failed to parse. Line
+// and BlockStart are 1-based line numbers into the input; BlockStart is
+// the line the enclosing block (cue, NOTE, STYLE, or REGION) began on,
+// which may be earlier than Line when the problem is deeper in the
+// block. Column is 1-based and zero when not applicable.
+type ParseError struct {
+	Line       int
+	Column     int
+	BlockStart int
+	Text       string
+	Msg        string
+}
+
+func (e *ParseError) Error() string {
+	if e.Column > 0 {
+		return fmt.Sprintf("webvtt: %s (line %d, column %d): %q", e.Msg, e.Line, e.Column, e.Text)
+	}
+	return fmt.Sprintf("webvtt: %s (line %d): %q", e.Msg, e.Line, e.Text)
+}
+
+// ParseOptions controls how Parse behaves when it encounters a malformed
+// block.
+type ParseOptions struct {
+	// ContinueOnError makes Parse yield a *ParseError for a broken block
+	// and keep parsing subsequent blocks, instead of stopping at the
+	// first error. Useful when ingesting user-uploaded captions or lossy
+	// MP4-extracted cues where one bad cue shouldn't discard the rest of
+	// the file.
+	ContinueOnError bool
+	// MaxErrors caps how many block errors ContinueOnError will tolerate
+	// before Parse stops early. Zero means unlimited.
+	MaxErrors int
+}
@@ -0,0 +1,102 @@
+package webvtt
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const multiCueWithBadBlock = "WEBVTT\n\n" +
+	"1\n00:00:01.000 --> 00:00:02.000\nfirst\n\n" +
+	"2\nnot a timestamp line\nbroken\n\n" +
+	"3\n00:00:05.000 --> 00:00:06.000\nthird\n"
+
+func TestParse_StopsAtFirstErrorByDefault(t *testing.T) {
+	var blocks []Block
+	var gotErr *ParseError
+	for block, err := range Parse(strings.NewReader(multiCueWithBadBlock)) {
+		if err != nil {
+			var perr *ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("expected *ParseError, got %T: %v", err, err)
+			}
+			gotErr = perr
+			break
+		}
+		blocks = append(blocks, block)
+	}
+	if len(blocks) != 1 {
+		t.Fatalf("got %d blocks before the error, want 1", len(blocks))
+	}
+	if gotErr == nil {
+		t.Fatal("expected a *ParseError, got none")
+	}
+	if gotErr.Line != 8 {
+		t.Errorf("ParseError.Line = %d, want 8", gotErr.Line)
+	}
+	if gotErr.BlockStart != 7 {
+		t.Errorf("ParseError.BlockStart = %d, want 7", gotErr.BlockStart)
+	}
+}
+
+func TestParse_ContinueOnError(t *testing.T) {
+	opts := ParseOptions{ContinueOnError: true}
+
+	var cues []Cue
+	var errs []*ParseError
+	for block, err := range Parse(strings.NewReader(multiCueWithBadBlock), opts) {
+		if err != nil {
+			var perr *ParseError
+			if errors.As(err, &perr) {
+				errs = append(errs, perr)
+			}
+			continue
+		}
+		if cue, ok := block.(Cue); ok {
+			cues = append(cues, cue)
+		}
+	}
+
+	if len(errs) != 1 {
+		t.Fatalf("got %d errors, want 1", len(errs))
+	}
+	if len(cues) != 2 {
+		t.Fatalf("got %d cues, want 2 (first and third block should survive)", len(cues))
+	}
+	if cues[0].ID != "1" || cues[1].ID != "3" {
+		t.Errorf("cues = %q, %q, want \"1\", \"3\"", cues[0].ID, cues[1].ID)
+	}
+}
+
+func TestParse_ColumnForBadTimestampValue(t *testing.T) {
+	input := "WEBVTT\n\n1\n00:0x:01.000 --> 00:00:02.000\nhi\n"
+
+	var gotErr *ParseError
+	for _, err := range Parse(strings.NewReader(input)) {
+		if err != nil {
+			if !errors.As(err, &gotErr) {
+				t.Fatalf("expected *ParseError, got %T: %v", err, err)
+			}
+			break
+		}
+	}
+	if gotErr == nil {
+		t.Fatal("expected a *ParseError, got none")
+	}
+	if gotErr.Column != 1 {
+		t.Errorf("ParseError.Column = %d, want 1 (offset of the malformed start timestamp)", gotErr.Column)
+	}
+}
+
+func TestParseAllWithOptions(t *testing.T) {
+	vtt, errs, err := ParseAllWithOptions(strings.NewReader(multiCueWithBadBlock), ParseOptions{ContinueOnError: true})
+	if err != nil {
+		t.Fatalf("unexpected fatal error: %v", err)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("got %d errors, want 1", len(errs))
+	}
+	if len(vtt.Cues) != 2 {
+		t.Fatalf("got %d cues, want 2", len(vtt.Cues))
+	}
+}
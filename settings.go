@@ -0,0 +1,111 @@
+package webvtt
+
+import (
+	"strconv"
+	"strings"
+)
+
+// VerticalDirection is the value of a cue's "vertical" setting.
+type VerticalDirection string
+
+const (
+	// VerticalHorizontal is the default: horizontal text growing downward.
+	VerticalHorizontal VerticalDirection = ""
+	VerticalRL         VerticalDirection = "rl"
+	VerticalLR         VerticalDirection = "lr"
+)
+
+// LineUnit distinguishes the two forms a "line" setting's value can take.
+type LineUnit int
+
+const (
+	// LineUnitNumber is a line number, e.g. "line:3".
+	LineUnitNumber LineUnit = iota
+	// LineUnitPercent is a percentage of the video height, e.g. "line:50%".
+	LineUnitPercent
+)
+
+// LineSetting is the parsed value of a cue's "line" setting, e.g.
+// "line:50%,center".
+type LineSetting struct {
+	Value float64
+	Unit  LineUnit
+	// Align is the optional alignment following a comma ("start",
+	// "center", or "end"), empty when not given.
+	Align string
+}
+
+// PositionSetting is the parsed value of a cue's "position" setting, e.g.
+// "position:10%,line-left". Value is a percentage.
+type PositionSetting struct {
+	Value float64
+	// Align is the optional alignment following a comma ("line-left",
+	// "center", or "line-right"), empty when not given.
+	Align string
+}
+
+// SizeSetting is the parsed value of a cue's "size" setting, a
+// percentage, e.g. "size:80%".
+type SizeSetting struct {
+	Value float64
+}
+
+// ScrollMode is the value of a REGION's "scroll" setting.
+type ScrollMode string
+
+const (
+	ScrollNone ScrollMode = ""
+	ScrollUp   ScrollMode = "up"
+)
+
+// Point is a percentage-based anchor point, used for REGION's
+// "regionanchor" and "viewportanchor" settings.
+type Point struct {
+	X, Y float64
+}
+
+func parseLineSetting(value string) LineSetting {
+	main, align, _ := strings.Cut(value, ",")
+	var ls LineSetting
+	ls.Align = align
+	if num, ok := strings.CutSuffix(main, "%"); ok {
+		ls.Unit = LineUnitPercent
+		ls.Value = atofLenient(num)
+	} else {
+		ls.Unit = LineUnitNumber
+		ls.Value = atofLenient(main)
+	}
+	return ls
+}
+
+func parsePositionSetting(value string) PositionSetting {
+	main, align, _ := strings.Cut(value, ",")
+	return PositionSetting{Value: parsePercentValue(main), Align: align}
+}
+
+func parseSizeSetting(value string) SizeSetting {
+	return SizeSetting{Value: parsePercentValue(value)}
+}
+
+// parsePoint parses an "X%,Y%" anchor such as REGION's "regionanchor" or
+// "viewportanchor" values.
+func parsePoint(value string) Point {
+	x, y, _ := strings.Cut(value, ",")
+	return Point{X: parsePercentValue(x), Y: parsePercentValue(y)}
+}
+
+func parsePercentValue(s string) float64 {
+	return atofLenient(strings.TrimSuffix(s, "%"))
+}
+
+// atofLenient parses a float, silently returning 0 for unparsable input,
+// consistent with how this package otherwise skips malformed setting
+// values rather than failing the whole cue.
+func atofLenient(s string) float64 {
+	v, _ := strconv.ParseFloat(s, 64)
+	return v
+}
+
+func formatPercentValue(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,120 @@
+package webvtt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCueSettings_Typed(t *testing.T) {
+	s := ParseCueSettings("vertical:rl line:50%,center position:10%,line-left size:80% align:center region:r0")
+
+	if s.Vertical != VerticalRL {
+		t.Errorf("Vertical = %q, want %q", s.Vertical, VerticalRL)
+	}
+	if s.Line == nil || s.Line.Value != 50 || s.Line.Unit != LineUnitPercent || s.Line.Align != "center" {
+		t.Errorf("Line = %+v", s.Line)
+	}
+	if s.Position == nil || s.Position.Value != 10 || s.Position.Align != "line-left" {
+		t.Errorf("Position = %+v", s.Position)
+	}
+	if s.Size == nil || s.Size.Value != 80 {
+		t.Errorf("Size = %+v", s.Size)
+	}
+	if s.Align != "center" {
+		t.Errorf("Align = %q", s.Align)
+	}
+	if s.Region != "r0" {
+		t.Errorf("Region = %q", s.Region)
+	}
+	if s.Raw["line"] != "50%,center" {
+		t.Errorf("Raw[line] = %q, want %q", s.Raw["line"], "50%,center")
+	}
+}
+
+func TestParseCueSettings_PlainLineNumber(t *testing.T) {
+	s := ParseCueSettings("line:3")
+	if s.Line == nil || s.Line.Unit != LineUnitNumber || s.Line.Value != 3 {
+		t.Errorf("Line = %+v, want {Value:3 Unit:Number}", s.Line)
+	}
+}
+
+func TestParseAll_Region(t *testing.T) {
+	input := "WEBVTT\n\n" +
+		"REGION\n" +
+		"id:fred\n" +
+		"width:40%\n" +
+		"lines:3\n" +
+		"regionanchor:0%,100%\n" +
+		"viewportanchor:10%,90%\n" +
+		"scroll:up\n\n" +
+		"1\n00:00:00.000 --> 00:00:01.000\nhi\n"
+
+	var blocks []Block
+	for block, err := range Parse(strings.NewReader(input)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		blocks = append(blocks, block)
+	}
+	if len(blocks) != 2 {
+		t.Fatalf("got %d blocks, want 2", len(blocks))
+	}
+	region, ok := blocks[0].(Region)
+	if !ok {
+		t.Fatalf("blocks[0] is %T, want Region", blocks[0])
+	}
+	if region.ID != "fred" {
+		t.Errorf("ID = %q", region.ID)
+	}
+	if region.Width == nil || *region.Width != 40 {
+		t.Errorf("Width = %v", region.Width)
+	}
+	if region.Lines == nil || *region.Lines != 3 {
+		t.Errorf("Lines = %v", region.Lines)
+	}
+	if region.RegionAnchor == nil || *region.RegionAnchor != (Point{X: 0, Y: 100}) {
+		t.Errorf("RegionAnchor = %v", region.RegionAnchor)
+	}
+	if region.ViewportAnchor == nil || *region.ViewportAnchor != (Point{X: 10, Y: 90}) {
+		t.Errorf("ViewportAnchor = %v", region.ViewportAnchor)
+	}
+	if region.Scroll != ScrollUp {
+		t.Errorf("Scroll = %q, want %q", region.Scroll, ScrollUp)
+	}
+}
+
+func TestWriteBlocks_RegionRoundTrip(t *testing.T) {
+	input := "WEBVTT\n\n" +
+		"REGION\n" +
+		"id:fred\n" +
+		"width:40%\n" +
+		"lines:3\n" +
+		"regionanchor:0%,100%\n" +
+		"viewportanchor:10%,90%\n" +
+		"scroll:up\n\n" +
+		"1\n00:00:00.000 --> 00:00:01.000 region:fred line:50%,center\nhi\n"
+
+	var buf strings.Builder
+	if err := WriteBlocks(&buf, Parse(strings.NewReader(input))); err != nil {
+		t.Fatal(err)
+	}
+
+	var blocks []Block
+	for block, err := range Parse(strings.NewReader(buf.String())) {
+		if err != nil {
+			t.Fatalf("re-parsing written output: %v", err)
+		}
+		blocks = append(blocks, block)
+	}
+	if len(blocks) != 2 {
+		t.Fatalf("got %d blocks after round trip, want 2: output was:\n%s", len(blocks), buf.String())
+	}
+	region := blocks[0].(Region)
+	if region.ID != "fred" || region.Scroll != ScrollUp || region.Width == nil || *region.Width != 40 {
+		t.Errorf("round-tripped region = %+v", region)
+	}
+	cue := blocks[1].(Cue)
+	if cue.Settings.Region != "fred" || cue.Settings.Line == nil || cue.Settings.Line.Align != "center" {
+		t.Errorf("round-tripped cue settings = %+v", cue.Settings)
+	}
+}
@@ -0,0 +1,159 @@
+package webvtt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// srtTimestampLineRegex matches an SRT timestamp line, which uses "," as
+// the millisecond separator and always spells out the hour, e.g.
+// "00:00:01,000 --> 00:00:04,000".
+var srtTimestampLineRegex = regexp.MustCompile(`^\d{2}:\d{2}:\d{2}[,.]\d{3}\s*-->`)
+
+// FromSRT reads a SubRip (.srt) stream and converts it to a *WebVTT using
+// the same Cue model Parse produces, so VTT- and SRT-sourced cues can be
+// handled identically. It tolerates "," as well as "." for the
+// millisecond separator, numeric-only cue IDs, and CRLF line endings.
+func FromSRT(r io.Reader) (*WebVTT, error) {
+	vtt := &WebVTT{}
+	scanner := bufio.NewScanner(r)
+
+	var lines []string
+	flush := func() error {
+		if len(lines) == 0 {
+			return nil
+		}
+		cue, err := parseSRTBlock(lines)
+		if err != nil {
+			return err
+		}
+		vtt.Cues = append(vtt.Cues, cue)
+		lines = nil
+		return nil
+	}
+
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if line == "" {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return vtt, nil
+}
+
+func parseSRTBlock(lines []string) (Cue, error) {
+	var cue Cue
+	idx := 0
+
+	if !srtTimestampLineRegex.MatchString(lines[idx]) {
+		cue.ID = strings.TrimSpace(lines[idx])
+		idx++
+	}
+
+	if idx >= len(lines) {
+		return cue, errors.New("webvtt: srt block missing timestamp line")
+	}
+
+	start, end, err := parseSRTTimestampLine(lines[idx])
+	if err != nil {
+		return cue, err
+	}
+	cue.StartTime = start
+	cue.EndTime = end
+	idx++
+
+	if idx < len(lines) {
+		text := strings.Join(lines[idx:], "\n")
+		cue.Voices = parseVoices(text)
+		cue.Nodes = parseCueNodes(text)
+	}
+
+	return cue, nil
+}
+
+func parseSRTTimestampLine(line string) (start, end time.Duration, err error) {
+	parts := strings.SplitN(line, "-->", 2)
+	if len(parts) != 2 {
+		return 0, 0, errors.New("webvtt: invalid srt timestamp line")
+	}
+
+	start, err = parseSRTTimestamp(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid start timestamp: %w", err)
+	}
+	end, err = parseSRTTimestamp(strings.TrimSpace(strings.Fields(parts[1])[0]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid end timestamp: %w", err)
+	}
+	return start, end, nil
+}
+
+func parseSRTTimestamp(s string) (time.Duration, error) {
+	return parseTimestamp(strings.Replace(s, ",", ".", 1))
+}
+
+// ToSRT writes vtt as a SubRip (.srt) stream. WebVTT-only constructs
+// (cue settings, regions, styles) have no SRT equivalent and are
+// dropped; voice spans are collapsed to "Speaker: text" lines (or plain
+// text for anonymous voices), and cues are renumbered sequentially.
+func ToSRT(w io.Writer, vtt *WebVTT) error {
+	for i, cue := range vtt.Cues {
+		if i > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+		if err := writeSRTCue(w, i+1, cue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeSRTCue(w io.Writer, n int, cue Cue) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d\n", n)
+	fmt.Fprintf(&b, "%s --> %s\n", formatSRTTimestamp(cue.StartTime), formatSRTTimestamp(cue.EndTime))
+	for i, voice := range cue.Voices {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		if voice.Speaker != "" {
+			fmt.Fprintf(&b, "%s: %s", voice.Speaker, voice.Text)
+		} else {
+			b.WriteString(voice.Text)
+		}
+	}
+	b.WriteByte('\n')
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// formatSRTTimestamp formats d as "HH:MM:SS,mmm"; unlike VTT timestamps,
+// SRT always spells out the hour.
+func formatSRTTimestamp(d time.Duration) string {
+	ms := d.Milliseconds()
+	hours := ms / int64(time.Hour/time.Millisecond)
+	ms -= hours * int64(time.Hour/time.Millisecond)
+	minutes := ms / int64(time.Minute/time.Millisecond)
+	ms -= minutes * int64(time.Minute/time.Millisecond)
+	seconds := ms / int64(time.Second/time.Millisecond)
+	ms -= seconds * int64(time.Second/time.Millisecond)
+	return fmt.Sprintf("%02d:%02d:%02d,%03d", hours, minutes, seconds, ms)
+}
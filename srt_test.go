@@ -0,0 +1,86 @@
+package webvtt
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFromSRT(t *testing.T) {
+	input := "1\r\n00:00:01,000 --> 00:00:04,500\r\nHello world\r\n\r\n" +
+		"2\n00:01:02,250 --> 00:01:03,000\nSecond line\n"
+
+	vtt, err := FromSRT(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vtt.Cues) != 2 {
+		t.Fatalf("got %d cues, want 2", len(vtt.Cues))
+	}
+
+	c0 := vtt.Cues[0]
+	if c0.ID != "1" {
+		t.Errorf("cue[0].ID = %q, want %q", c0.ID, "1")
+	}
+	if c0.StartTime != time.Second || c0.EndTime != 4*time.Second+500*time.Millisecond {
+		t.Errorf("cue[0] times = %v..%v", c0.StartTime, c0.EndTime)
+	}
+	if len(c0.Voices) != 1 || c0.Voices[0].Text != "Hello world" {
+		t.Errorf("cue[0].Voices = %+v", c0.Voices)
+	}
+
+	c1 := vtt.Cues[1]
+	wantStart := time.Minute + 2*time.Second + 250*time.Millisecond
+	if c1.StartTime != wantStart {
+		t.Errorf("cue[1].StartTime = %v, want %v", c1.StartTime, wantStart)
+	}
+}
+
+func TestToSRT(t *testing.T) {
+	vtt := &WebVTT{
+		Cues: []Cue{
+			{
+				ID:        "intro",
+				StartTime: time.Second,
+				EndTime:   4*time.Second + 500*time.Millisecond,
+				Settings:  CueSettings{Align: "center"},
+				Voices:    []Voice{{Speaker: "Bob", Text: "Hi"}},
+			},
+			{
+				StartTime: time.Hour + time.Second,
+				EndTime:   time.Hour + 2*time.Second,
+				Voices:    []Voice{{Text: "anonymous"}},
+			},
+		},
+	}
+
+	var buf strings.Builder
+	if err := ToSRT(&buf, vtt); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "1\n00:00:01,000 --> 00:00:04,500\nBob: Hi\n\n" +
+		"2\n01:00:01,000 --> 01:00:02,000\nanonymous\n"
+	if buf.String() != want {
+		t.Errorf("ToSRT() =\n%q\nwant\n%q", buf.String(), want)
+	}
+}
+
+func TestSRTRoundTrip(t *testing.T) {
+	vtt := &WebVTT{Cues: []Cue{
+		{StartTime: time.Second, EndTime: 2 * time.Second, Voices: []Voice{{Text: "hi"}}},
+	}}
+
+	var buf strings.Builder
+	if err := ToSRT(&buf, vtt); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := FromSRT(strings.NewReader(buf.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Cues) != 1 || got.Cues[0].StartTime != time.Second || got.Cues[0].EndTime != 2*time.Second {
+		t.Errorf("round trip = %+v", got.Cues)
+	}
+}
@@ -39,18 +39,30 @@ type Cue struct {
 	EndTime   time.Duration
 	Settings  CueSettings
 	Voices    []Voice
+	// Nodes is the parsed cue-text tree: <c>, <i>, <b>, <u>, <ruby>/<rt>,
+	// <lang>, <v>, and timestamp tags, in document order. Voices is kept
+	// alongside it for callers that only need the flattened voice-span view.
+	Nodes []CueNode
 }
 
 func (c Cue) blockType() BlockType { return BlockTypeCue }
 
 // CueSettings represents cue settings
 type CueSettings struct {
-	Vertical string
-	Line     string
-	Position string
-	Size     string
-	Align    string
-	Region   string
+	Vertical VerticalDirection
+	// Line is nil when no "line" setting was given.
+	Line *LineSetting
+	// Position is nil when no "position" setting was given.
+	Position *PositionSetting
+	// Size is nil when no "size" setting was given.
+	Size  *SizeSetting
+	Align string
+	// Region is the id of the region this cue attaches to, if any.
+	Region string
+	// Raw holds every cue-settings key:value pair exactly as written, for
+	// callers that need the original text or a setting this package
+	// doesn't model yet.
+	Raw map[string]string
 }
 
 // Voice represents a voice span in cue text
@@ -75,8 +87,20 @@ func (s Style) blockType() BlockType { return BlockTypeStyle }
 
 // Region represents a REGION block
 type Region struct {
-	ID       string
-	Settings map[string]string
+	ID string
+	// Width is nil when no "width" setting was given.
+	Width *float64
+	// Lines is nil when no "lines" setting was given.
+	Lines *int
+	// RegionAnchor is nil when no "regionanchor" setting was given.
+	RegionAnchor *Point
+	// ViewportAnchor is nil when no "viewportanchor" setting was given.
+	ViewportAnchor *Point
+	Scroll         ScrollMode
+	// Raw holds every REGION setting key:value pair exactly as written,
+	// for callers that need the original text or a setting this package
+	// doesn't model yet.
+	Raw map[string]string
 }
 
 func (r Region) blockType() BlockType { return BlockTypeRegion }
@@ -89,61 +113,91 @@ var (
 )
 
 // Parse parses WebVTT content and returns an iterator of blocks
-func Parse(r io.Reader) iter.Seq2[Block, error] {
+func Parse(r io.Reader, opts ...ParseOptions) iter.Seq2[Block, error] {
+	var opt ParseOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
 	return func(yield func(Block, error) bool) {
 		scanner := bufio.NewScanner(r)
+		lineNo := 0
+		scan := func() bool {
+			ok := scanner.Scan()
+			if ok {
+				lineNo++
+			}
+			return ok
+		}
 
 		// Check WEBVTT header
-		if !scanner.Scan() {
+		if !scan() {
 			if err := scanner.Err(); err != nil {
 				yield(nil, err)
 			} else {
-				yield(nil, errors.New("empty input"))
+				yield(nil, &ParseError{Line: lineNo + 1, Msg: "empty input"})
 			}
 			return
 		}
 		header := scanner.Text()
 		if !strings.HasPrefix(header, "WEBVTT") {
-			yield(nil, errors.New("missing WEBVTT header"))
+			yield(nil, &ParseError{Line: lineNo, Text: header, Msg: "missing WEBVTT header"})
 			return
 		}
 
 		var lines []string
-		for scanner.Scan() {
+		blockStart := 0
+		errCount := 0
+
+		// emitBlock parses and yields the accumulated block, if any. It
+		// reports whether the caller should keep scanning: false means
+		// either the consumer stopped the iterator, or a block failed to
+		// parse and either opt.ContinueOnError is unset or opt.MaxErrors
+		// errors have now been reported.
+		emitBlock := func() bool {
+			if len(lines) == 0 {
+				return true
+			}
+			block, perr := parseBlock(lines, blockStart)
+			lines = nil
+			if perr != nil {
+				errCount++
+				if !yield(nil, perr) {
+					return false
+				}
+				if !opt.ContinueOnError {
+					return false
+				}
+				if opt.MaxErrors > 0 && errCount >= opt.MaxErrors {
+					return false
+				}
+				return true
+			}
+			if block != nil {
+				return yield(block, nil)
+			}
+			return true
+		}
+
+		for scan() {
 			line := scanner.Text()
 
 			if line == "" {
 				// Empty line = end of block
-				if len(lines) > 0 {
-					block, err := parseBlock(lines)
-					if err != nil {
-						if !yield(nil, err) {
-							return
-						}
-					} else if block != nil {
-						if !yield(block, nil) {
-							return
-						}
-					}
-					lines = nil
+				if !emitBlock() {
+					return
 				}
 				continue
 			}
+			if len(lines) == 0 {
+				blockStart = lineNo
+			}
 			lines = append(lines, line)
 		}
 
 		// Handle last block
-		if len(lines) > 0 {
-			block, err := parseBlock(lines)
-			if err != nil {
-				yield(nil, err)
-				return
-			}
-			if block != nil {
-				if !yield(block, nil) {
-					return
-				}
-			}
+		if !emitBlock() {
+			return
 		}
 
 		if err := scanner.Err(); err != nil {
@@ -152,7 +206,10 @@ func Parse(r io.Reader) iter.Seq2[Block, error] {
 	}
 }
 
-// ParseAll parses WebVTT content and returns a WebVTT struct containing all cues
+// ParseAll parses WebVTT content and returns a WebVTT struct containing
+// all cues. It stops at the first error, same as Parse with the zero
+// ParseOptions. Use ParseAllWithOptions to recover from malformed blocks
+// instead of aborting the whole file.
 func ParseAll(r io.Reader) (*WebVTT, error) {
 	vtt := &WebVTT{}
 	for block, err := range Parse(r) {
@@ -166,7 +223,31 @@ func ParseAll(r io.Reader) (*WebVTT, error) {
 	return vtt, nil
 }
 
-func parseBlock(lines []string) (Block, error) {
+// ParseAllWithOptions is like ParseAll, but parses with opts. When
+// opts.ContinueOnError is set, a malformed block is recorded as a
+// *ParseError in the returned slice instead of aborting the parse, so
+// one bad cue doesn't discard the rest of the file. A non-ParseError
+// (e.g. an I/O error from r) still aborts immediately.
+func ParseAllWithOptions(r io.Reader, opts ParseOptions) (*WebVTT, []*ParseError, error) {
+	vtt := &WebVTT{}
+	var errs []*ParseError
+	for block, err := range Parse(r, opts) {
+		if err != nil {
+			var perr *ParseError
+			if errors.As(err, &perr) {
+				errs = append(errs, perr)
+				continue
+			}
+			return nil, errs, err
+		}
+		if cue, ok := block.(Cue); ok {
+			vtt.Cues = append(vtt.Cues, cue)
+		}
+	}
+	return vtt, errs, nil
+}
+
+func parseBlock(lines []string, blockStart int) (Block, *ParseError) {
 	if len(lines) == 0 {
 		return nil, nil
 	}
@@ -189,7 +270,7 @@ func parseBlock(lines []string) (Block, error) {
 
 	// REGION block
 	if first == "REGION" || strings.HasPrefix(first, "REGION") {
-		region := Region{Settings: make(map[string]string)}
+		region := Region{Raw: make(map[string]string)}
 		startIdx := 0
 		if first == "REGION" {
 			startIdx = 1
@@ -201,24 +282,45 @@ func parseBlock(lines []string) (Block, error) {
 			if line == "REGION" {
 				continue
 			}
-			if idx := strings.Index(line, ":"); idx > 0 {
-				key := strings.TrimSpace(line[:idx])
-				value := strings.TrimSpace(line[idx+1:])
-				if key == "id" {
-					region.ID = value
-				} else {
-					region.Settings[key] = value
+			idx := strings.Index(line, ":")
+			if idx <= 0 {
+				continue
+			}
+			key := strings.TrimSpace(line[:idx])
+			value := strings.TrimSpace(line[idx+1:])
+			region.Raw[key] = value
+			switch key {
+			case "id":
+				region.ID = value
+			case "width":
+				width := parsePercentValue(value)
+				region.Width = &width
+			case "lines":
+				if n, err := strconv.Atoi(value); err == nil {
+					region.Lines = &n
 				}
+			case "regionanchor":
+				anchor := parsePoint(value)
+				region.RegionAnchor = &anchor
+			case "viewportanchor":
+				anchor := parsePoint(value)
+				region.ViewportAnchor = &anchor
+			case "scroll":
+				region.Scroll = ScrollMode(value)
 			}
 		}
 		return region, nil
 	}
 
 	// Cue block
-	return parseCue(lines)
+	cue, perr := parseCue(lines, blockStart)
+	if perr != nil {
+		return nil, perr
+	}
+	return cue, nil
 }
 
-func parseCue(lines []string) (Cue, error) {
+func parseCue(lines []string, blockStart int) (Cue, *ParseError) {
 	var cue Cue
 	idx := 0
 
@@ -229,14 +331,25 @@ func parseCue(lines []string) (Cue, error) {
 	}
 
 	if idx >= len(lines) {
-		return cue, errors.New("missing timestamp line")
+		return cue, &ParseError{
+			Line:       blockStart + len(lines) - 1,
+			BlockStart: blockStart,
+			Text:       lines[len(lines)-1],
+			Msg:        "missing timestamp line",
+		}
 	}
 
 	// Parse timestamp line
 	tsLine := lines[idx]
-	startTime, endTime, settings, err := parseTimestampLine(tsLine)
+	startTime, endTime, settings, column, err := parseTimestampLine(tsLine)
 	if err != nil {
-		return cue, err
+		return cue, &ParseError{
+			Line:       blockStart + idx,
+			Column:     column,
+			BlockStart: blockStart,
+			Text:       tsLine,
+			Msg:        err.Error(),
+		}
 	}
 	cue.StartTime = startTime
 	cue.EndTime = endTime
@@ -247,17 +360,22 @@ func parseCue(lines []string) (Cue, error) {
 	if idx < len(lines) {
 		text := strings.Join(lines[idx:], "\n")
 		cue.Voices = parseVoices(text)
+		cue.Nodes = parseCueNodes(text)
 	}
 
 	return cue, nil
 }
 
-func parseTimestampLine(line string) (start, end time.Duration, settings CueSettings, err error) {
+// parseTimestampLine parses a cue's "start --> end settings" line. column is
+// the 1-based offset into line of the value that failed to parse, or 0 when
+// err describes the line's overall structure rather than a specific value.
+func parseTimestampLine(line string) (start, end time.Duration, settings CueSettings, column int, err error) {
 	// Split by "-->"
-	parts := strings.SplitN(line, "-->", 2)
-	if len(parts) != 2 {
-		return 0, 0, settings, errors.New("invalid timestamp line")
+	arrow := strings.Index(line, "-->")
+	if arrow < 0 {
+		return 0, 0, settings, 0, errors.New("invalid timestamp line")
 	}
+	parts := []string{line[:arrow], line[arrow+3:]}
 
 	startStr := strings.TrimSpace(parts[0])
 	rest := strings.TrimSpace(parts[1])
@@ -265,44 +383,63 @@ func parseTimestampLine(line string) (start, end time.Duration, settings CueSett
 	// End time and optional settings
 	restParts := strings.Fields(rest)
 	if len(restParts) == 0 {
-		return 0, 0, settings, errors.New("missing end timestamp")
+		return 0, 0, settings, arrow + 4, errors.New("missing end timestamp")
 	}
 
 	endStr := restParts[0]
 
 	start, err = parseTimestamp(startStr)
 	if err != nil {
-		return 0, 0, settings, fmt.Errorf("invalid start timestamp: %w", err)
+		return 0, 0, settings, strings.Index(line, startStr) + 1, fmt.Errorf("invalid start timestamp: %w", err)
 	}
 
 	end, err = parseTimestamp(endStr)
 	if err != nil {
-		return 0, 0, settings, fmt.Errorf("invalid end timestamp: %w", err)
+		return 0, 0, settings, arrow + 3 + strings.Index(line[arrow+3:], endStr) + 1, fmt.Errorf("invalid end timestamp: %w", err)
 	}
 
-	// Parse settings
-	for _, s := range restParts[1:] {
-		if idx := strings.Index(s, ":"); idx > 0 {
-			key := s[:idx]
-			value := s[idx+1:]
-			switch key {
-			case "vertical":
-				settings.Vertical = value
-			case "line":
-				settings.Line = value
-			case "position":
-				settings.Position = value
-			case "size":
-				settings.Size = value
-			case "align":
-				settings.Align = value
-			case "region":
-				settings.Region = value
-			}
+	settings = parseCueSettingsFields(restParts[1:])
+
+	return start, end, settings, 0, nil
+}
+
+// ParseCueSettings parses a cue settings string, such as "line:50% align:center"
+// (the portion of a timestamp line after the two timestamps, or an ISOBMFF
+// 'sttg' box payload), into a CueSettings value.
+func ParseCueSettings(s string) CueSettings {
+	return parseCueSettingsFields(strings.Fields(s))
+}
+
+func parseCueSettingsFields(fields []string) CueSettings {
+	settings := CueSettings{Raw: make(map[string]string)}
+	for _, s := range fields {
+		idx := strings.Index(s, ":")
+		if idx <= 0 {
+			continue
+		}
+		key := s[:idx]
+		value := s[idx+1:]
+		settings.Raw[key] = value
+		switch key {
+		case "vertical":
+			settings.Vertical = VerticalDirection(value)
+		case "line":
+			ls := parseLineSetting(value)
+			settings.Line = &ls
+		case "position":
+			ps := parsePositionSetting(value)
+			settings.Position = &ps
+		case "size":
+			ss := parseSizeSetting(value)
+			settings.Size = &ss
+		case "align":
+			settings.Align = value
+		case "region":
+			settings.Region = value
 		}
 	}
 
-	return start, end, settings, nil
+	return settings
 }
 
 func parseTimestamp(s string) (time.Duration, error) {
@@ -359,6 +496,20 @@ func parseTimestamp(s string) (time.Duration, error) {
 		time.Duration(millis)*time.Millisecond, nil
 }
 
+// ParseCueText parses cue payload text, such as a 'payl' box from an
+// ISOBMFF wvtt sample, into the voice spans Parse would produce for the
+// same text in a sidecar .vtt file.
+func ParseCueText(text string) []Voice {
+	return parseVoices(text)
+}
+
+// ParseCueNodes parses cue payload text, such as a 'payl' box from an
+// ISOBMFF wvtt sample, into the cue-text tree Parse would produce for the
+// same text in a sidecar .vtt file.
+func ParseCueNodes(text string) []CueNode {
+	return parseCueNodes(text)
+}
+
 func parseVoices(text string) []Voice {
 	var voices []Voice
 
@@ -0,0 +1,336 @@
+package webvtt
+
+import (
+	"fmt"
+	"io"
+	"iter"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Write serializes vtt as a WebVTT stream, writing the header followed by
+// each cue in order.
+func Write(w io.Writer, vtt *WebVTT) error {
+	if _, err := io.WriteString(w, "WEBVTT\n\n"); err != nil {
+		return err
+	}
+	for i, cue := range vtt.Cues {
+		if i > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+		if err := writeCue(w, cue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteBlocks serializes an iterator of blocks, such as the one Parse
+// returns, back into a WebVTT stream. Unlike Write, it preserves block
+// order and emits NOTE, STYLE, and REGION blocks that WebVTT/ParseAll
+// otherwise discard.
+func WriteBlocks(w io.Writer, blocks iter.Seq2[Block, error]) error {
+	if _, err := io.WriteString(w, "WEBVTT\n\n"); err != nil {
+		return err
+	}
+	first := true
+	for block, err := range blocks {
+		if err != nil {
+			return err
+		}
+		if !first {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+		first = false
+		if err := writeBlock(w, block); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeBlock(w io.Writer, block Block) error {
+	switch b := block.(type) {
+	case Cue:
+		return writeCue(w, b)
+	case Note:
+		return writeNote(w, b)
+	case Style:
+		return writeStyle(w, b)
+	case Region:
+		return writeRegion(w, b)
+	default:
+		return fmt.Errorf("webvtt: unknown block type %T", block)
+	}
+}
+
+func writeCue(w io.Writer, cue Cue) error {
+	var b strings.Builder
+	if cue.ID != "" {
+		b.WriteString(cue.ID)
+		b.WriteByte('\n')
+	}
+	b.WriteString(formatTimestamp(cue.StartTime))
+	b.WriteString(" --> ")
+	b.WriteString(formatTimestamp(cue.EndTime))
+	if settings := formatCueSettings(cue.Settings); settings != "" {
+		b.WriteByte(' ')
+		b.WriteString(settings)
+	}
+	b.WriteByte('\n')
+	b.WriteString(formatCuePayload(cue))
+	b.WriteByte('\n')
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// formatCuePayload renders a cue's text. It prefers cue.Nodes, the parsed
+// cue-text tree, so tags other than <v> (<i>, <c>, <ruby>, timestamps,
+// ...) round-trip instead of being flattened and escaped; it falls back
+// to cue.Voices, rendered as one line per voice, for Cue values that only
+// set that field.
+func formatCuePayload(cue Cue) string {
+	if len(cue.Nodes) > 0 {
+		return renderCueNodes(cue.Nodes)
+	}
+	parts := make([]string, len(cue.Voices))
+	for i, voice := range cue.Voices {
+		parts[i] = formatVoice(voice)
+	}
+	return strings.Join(parts, "\n")
+}
+
+func writeNote(w io.Writer, note Note) error {
+	if strings.Contains(note.Text, "\n") {
+		_, err := fmt.Fprintf(w, "NOTE\n%s\n", note.Text)
+		return err
+	}
+	if note.Text == "" {
+		_, err := io.WriteString(w, "NOTE\n")
+		return err
+	}
+	_, err := fmt.Fprintf(w, "NOTE %s\n", note.Text)
+	return err
+}
+
+func writeStyle(w io.Writer, style Style) error {
+	_, err := fmt.Fprintf(w, "STYLE\n%s\n", style.Text)
+	return err
+}
+
+var knownRegionKeys = map[string]bool{
+	"id": true, "width": true, "lines": true,
+	"regionanchor": true, "viewportanchor": true, "scroll": true,
+}
+
+func writeRegion(w io.Writer, region Region) error {
+	var b strings.Builder
+	b.WriteString("REGION\n")
+	if region.ID != "" {
+		fmt.Fprintf(&b, "id:%s\n", region.ID)
+	}
+	if region.Width != nil {
+		fmt.Fprintf(&b, "width:%s%%\n", formatPercentValue(*region.Width))
+	}
+	if region.Lines != nil {
+		fmt.Fprintf(&b, "lines:%d\n", *region.Lines)
+	}
+	if region.RegionAnchor != nil {
+		fmt.Fprintf(&b, "regionanchor:%s\n", formatPoint(*region.RegionAnchor))
+	}
+	if region.ViewportAnchor != nil {
+		fmt.Fprintf(&b, "viewportanchor:%s\n", formatPoint(*region.ViewportAnchor))
+	}
+	if region.Scroll != "" {
+		fmt.Fprintf(&b, "scroll:%s\n", region.Scroll)
+	}
+	writeUnknownRaw(&b, region.Raw, knownRegionKeys)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func formatPoint(p Point) string {
+	return formatPercentValue(p.X) + "%," + formatPercentValue(p.Y) + "%"
+}
+
+var knownCueSettingsKeys = map[string]bool{
+	"vertical": true, "line": true, "position": true,
+	"size": true, "align": true, "region": true,
+}
+
+// formatCueSettings renders settings in the canonical order the WebVTT
+// spec lists them in: vertical, line, position, size, align, region,
+// followed by any settings this package doesn't model, taken from Raw.
+func formatCueSettings(s CueSettings) string {
+	var parts []string
+	if s.Vertical != "" {
+		parts = append(parts, "vertical:"+string(s.Vertical))
+	}
+	if s.Line != nil {
+		parts = append(parts, "line:"+formatLineSetting(*s.Line))
+	}
+	if s.Position != nil {
+		parts = append(parts, "position:"+formatPositionSetting(*s.Position))
+	}
+	if s.Size != nil {
+		parts = append(parts, "size:"+formatSizeSetting(*s.Size))
+	}
+	if s.Align != "" {
+		parts = append(parts, "align:"+s.Align)
+	}
+	if s.Region != "" {
+		parts = append(parts, "region:"+s.Region)
+	}
+	keys := make([]string, 0, len(s.Raw))
+	for k := range s.Raw {
+		if !knownCueSettingsKeys[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		parts = append(parts, k+":"+s.Raw[k])
+	}
+	return strings.Join(parts, " ")
+}
+
+func formatLineSetting(ls LineSetting) string {
+	v := formatPercentValue(ls.Value)
+	if ls.Unit == LineUnitPercent {
+		v += "%"
+	}
+	if ls.Align != "" {
+		v += "," + ls.Align
+	}
+	return v
+}
+
+func formatPositionSetting(ps PositionSetting) string {
+	v := formatPercentValue(ps.Value) + "%"
+	if ps.Align != "" {
+		v += "," + ps.Align
+	}
+	return v
+}
+
+func formatSizeSetting(ss SizeSetting) string {
+	return formatPercentValue(ss.Value) + "%"
+}
+
+// writeUnknownRaw appends any Raw key:value pairs not already covered by
+// typed fields, so settings this package doesn't model aren't silently
+// dropped on round trip.
+func writeUnknownRaw(b *strings.Builder, raw map[string]string, known map[string]bool) {
+	keys := make([]string, 0, len(raw))
+	for k := range raw {
+		if !known[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(b, "%s:%s\n", k, raw[k])
+	}
+}
+
+// renderCueNodes renders a parsed cue-text tree back into WebVTT cue-text
+// markup.
+func renderCueNodes(nodes []CueNode) string {
+	var b strings.Builder
+	for _, n := range nodes {
+		writeCueNode(&b, n)
+	}
+	return b.String()
+}
+
+var cueNodeTag = map[CueNodeKind]string{
+	CueNodeClass:     "c",
+	CueNodeItalic:    "i",
+	CueNodeBold:      "b",
+	CueNodeUnderline: "u",
+	CueNodeRuby:      "ruby",
+	CueNodeRubyText:  "rt",
+	CueNodeLang:      "lang",
+	CueNodeVoice:     "v",
+}
+
+func writeCueNode(b *strings.Builder, n CueNode) {
+	switch n.Kind {
+	case CueNodeText:
+		b.WriteString(escapeCueText(n.Text))
+	case CueNodeTimestamp:
+		b.WriteByte('<')
+		b.WriteString(formatTimestamp(n.Time))
+		b.WriteByte('>')
+	default:
+		tag := cueNodeTag[n.Kind]
+		b.WriteByte('<')
+		b.WriteString(tag)
+		for _, c := range n.Classes {
+			b.WriteByte('.')
+			b.WriteString(c)
+		}
+		if annotation := cueNodeAnnotation(n); annotation != "" {
+			b.WriteByte(' ')
+			b.WriteString(annotation)
+		}
+		b.WriteByte('>')
+		for _, child := range n.Children {
+			writeCueNode(b, child)
+		}
+		b.WriteString("</")
+		b.WriteString(tag)
+		b.WriteByte('>')
+	}
+}
+
+func cueNodeAnnotation(n CueNode) string {
+	switch n.Kind {
+	case CueNodeVoice:
+		return n.Voice
+	case CueNodeLang:
+		return n.Lang
+	}
+	return ""
+}
+
+// formatVoice re-wraps a voice span as "<v Speaker>...</v>", or renders it
+// as plain text when it has no speaker.
+func formatVoice(v Voice) string {
+	text := escapeCueText(v.Text)
+	if v.Speaker == "" {
+		return text
+	}
+	return "<v " + v.Speaker + ">" + text + "</v>"
+}
+
+var cueTextEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// escapeCueText escapes the characters that are significant to the cue-text
+// markup grammar so that literal "&", "<", ">" in cue payloads round-trip.
+func escapeCueText(s string) string {
+	return cueTextEscaper.Replace(s)
+}
+
+// formatTimestamp formats d using "HH:MM:SS.mmm" when it is an hour or
+// longer, and the shorter "MM:SS.mmm" form otherwise, mirroring the two
+// shapes parseTimestamp accepts.
+func formatTimestamp(d time.Duration) string {
+	ms := d.Milliseconds()
+	hours := ms / int64(time.Hour/time.Millisecond)
+	ms -= hours * int64(time.Hour/time.Millisecond)
+	minutes := ms / int64(time.Minute/time.Millisecond)
+	ms -= minutes * int64(time.Minute/time.Millisecond)
+	seconds := ms / int64(time.Second/time.Millisecond)
+	ms -= seconds * int64(time.Second/time.Millisecond)
+
+	if hours > 0 {
+		return fmt.Sprintf("%02d:%02d:%02d.%03d", hours, minutes, seconds, ms)
+	}
+	return fmt.Sprintf("%02d:%02d.%03d", minutes, seconds, ms)
+}
@@ -0,0 +1,90 @@
+package webvtt
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWrite_RoundTrip(t *testing.T) {
+	vtt := &WebVTT{
+		Cues: []Cue{
+			{
+				ID:        "1",
+				StartTime: time.Minute + 2*time.Second,
+				EndTime:   time.Minute + 4*time.Second + 500*time.Millisecond,
+				Settings:  CueSettings{Line: &LineSetting{Value: 50, Unit: LineUnitPercent}, Align: "center"},
+				Voices:    []Voice{{Speaker: "Bob", Text: "Hi <there> & welcome"}},
+			},
+			{
+				StartTime: time.Hour + time.Second,
+				EndTime:   time.Hour + 2*time.Second,
+				Voices:    []Voice{{Text: "anonymous cue"}},
+			},
+		},
+	}
+
+	var buf strings.Builder
+	if err := Write(&buf, vtt); err != nil {
+		t.Fatal(err)
+	}
+
+	got := buf.String()
+	want := "WEBVTT\n\n" +
+		"1\n01:02.000 --> 01:04.500 line:50% align:center\n<v Bob>Hi &lt;there&gt; &amp; welcome</v>\n\n" +
+		"01:00:01.000 --> 01:00:02.000\nanonymous cue\n"
+	if got != want {
+		t.Errorf("Write() =\n%q\nwant\n%q", got, want)
+	}
+
+	vtt2, err := ParseAll(strings.NewReader(got))
+	if err != nil {
+		t.Fatalf("ParseAll(Write(vtt)) failed: %v", err)
+	}
+	if len(vtt2.Cues) != len(vtt.Cues) {
+		t.Fatalf("round trip produced %d cues, want %d", len(vtt2.Cues), len(vtt.Cues))
+	}
+	if vtt2.Cues[0].StartTime != vtt.Cues[0].StartTime || vtt2.Cues[0].EndTime != vtt.Cues[0].EndTime {
+		t.Errorf("round trip cue[0] times = %v..%v, want %v..%v",
+			vtt2.Cues[0].StartTime, vtt2.Cues[0].EndTime, vtt.Cues[0].StartTime, vtt.Cues[0].EndTime)
+	}
+}
+
+func TestWrite_CueNodesRoundTrip(t *testing.T) {
+	input := "WEBVTT\n\n" +
+		"1\n00:00:00.000 --> 00:00:01.000\n<i>loud</i> hi <v Bob>there</v>\n"
+
+	vtt, err := ParseAll(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf strings.Builder
+	if err := Write(&buf, vtt); err != nil {
+		t.Fatal(err)
+	}
+
+	got := buf.String()
+	if !strings.Contains(got, "<i>loud</i> hi <v Bob>there</v>") {
+		t.Errorf("Write() = %q, want cue-text markup preserved, not escaped", got)
+	}
+}
+
+func TestWriteBlocks(t *testing.T) {
+	input := "WEBVTT\n\n" +
+		"NOTE a comment\n\n" +
+		"STYLE\n::cue { color: red; }\n\n" +
+		"1\n00:00:01.000 --> 00:00:02.000\nhello\n"
+
+	var buf strings.Builder
+	if err := WriteBlocks(&buf, Parse(strings.NewReader(input))); err != nil {
+		t.Fatal(err)
+	}
+
+	got := buf.String()
+	for _, want := range []string{"WEBVTT", "NOTE a comment", "STYLE", "::cue { color: red; }", "hello"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("WriteBlocks() output missing %q, got:\n%s", want, got)
+		}
+	}
+}